@@ -0,0 +1,22 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlock drains the payload-pruning schedule, deleting any ProvenPayload
+// entries proven Params.PayloadRetentionBlocks or more blocks before the
+// current height.
+func (k Keeper) BeginBlock(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.Pruner.Prune(ctx, uint64(sdkCtx.BlockHeight()), params.PayloadRetentionBlocks, params.MaxPrunedPerBlock)
+	return err
+}
@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+var _ zktx.QueryServer = Keeper{}
+
+// PayloadsByContract implements the QueryPayloadsByContract gRPC method, returning
+// a paginated list of payloads proven for a single contract.
+func (k Keeper) PayloadsByContract(ctx context.Context, req *zktx.QueryPayloadsByContractRequest) (*zktx.QueryPayloadsByContractResponse, error) {
+	results, pageRes, err := query.CollectionPaginate(
+		ctx, k.ProvenPayload.Indexes.Contract, req.Pagination,
+		func(_ PayloadKey, payload zktx.PayloadMetadata) (zktx.PayloadMetadata, error) {
+			return payload, nil
+		},
+		query.WithCollectionPaginationPairPrefix[string, PayloadKey](req.ContractName),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zktx.QueryPayloadsByContractResponse{Payloads: results, Pagination: pageRes}, nil
+}
+
+// PayloadsByHeight implements the QueryPayloadsByHeight gRPC method, returning a
+// paginated list of payloads submitted at a single block height.
+func (k Keeper) PayloadsByHeight(ctx context.Context, req *zktx.QueryPayloadsByHeightRequest) (*zktx.QueryPayloadsByHeightResponse, error) {
+	results, pageRes, err := query.CollectionPaginate(
+		ctx, k.ProvenPayload.Indexes.Height, req.Pagination,
+		func(_ PayloadKey, payload zktx.PayloadMetadata) (zktx.PayloadMetadata, error) {
+			return payload, nil
+		},
+		query.WithCollectionPaginationPairPrefix[uint64, PayloadKey](req.Height),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zktx.QueryPayloadsByHeightResponse{Payloads: results, Pagination: pageRes}, nil
+}
+
+// PayloadsByProver implements the QueryPayloadsByProver gRPC method, returning a
+// paginated list of payloads submitted by a single prover address.
+func (k Keeper) PayloadsByProver(ctx context.Context, req *zktx.QueryPayloadsByProverRequest) (*zktx.QueryPayloadsByProverResponse, error) {
+	results, pageRes, err := query.CollectionPaginate(
+		ctx, k.ProvenPayload.Indexes.Prover, req.Pagination,
+		func(_ PayloadKey, payload zktx.PayloadMetadata) (zktx.PayloadMetadata, error) {
+			return payload, nil
+		},
+		query.WithCollectionPaginationPairPrefix[string, PayloadKey](req.Prover),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zktx.QueryPayloadsByProverResponse{Payloads: results, Pagination: pageRes}, nil
+}
+
+// PendingPruneCount implements the QueryPendingPruneCount gRPC method, reporting
+// how many ProvenPayload entries are still queued for deletion by the pruner.
+func (k Keeper) PendingPruneCount(ctx context.Context, _ *zktx.QueryPendingPruneCountRequest) (*zktx.QueryPendingPruneCountResponse, error) {
+	count, err := k.Pruner.PendingCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zktx.QueryPendingPruneCountResponse{PendingCount: count}, nil
+}
+
+// ProofsByRange implements the QueryProofsByRange gRPC method, letting
+// relayers and indexers stream accepted proofs in submission order starting
+// at FromId. It paginates over the ProofID unique index the same way the
+// sibling handlers above paginate over theirs, so a caller can't force an
+// unbounded response (and unbounded keeper memory use) by passing a wide
+// range -- each call returns at most one page, with Pagination.NextKey set
+// to resume. ToId, if set, is applied as a bound on top of the returned page.
+func (k Keeper) ProofsByRange(ctx context.Context, req *zktx.QueryProofsByRangeRequest) (*zktx.QueryProofsByRangeResponse, error) {
+	pageReq := req.Pagination
+	if pageReq == nil {
+		pageReq = &query.PageRequest{}
+	}
+	if len(pageReq.Key) == 0 {
+		keyCodec := collections.Uint64Key
+		start := make([]byte, keyCodec.Size(req.FromId))
+		if _, err := keyCodec.Encode(start, req.FromId); err != nil {
+			return nil, err
+		}
+		pageReq.Key = start
+	}
+
+	results, pageRes, err := query.CollectionPaginate(
+		ctx, k.ProvenPayload.Indexes.ProofID, pageReq,
+		func(_ PayloadKey, payload zktx.PayloadMetadata) (zktx.PayloadMetadata, error) {
+			return payload, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ToId > 0 {
+		bounded := results[:0]
+		for _, payload := range results {
+			if payload.ProofID >= req.ToId {
+				break
+			}
+			bounded = append(bounded, payload)
+		}
+		results = bounded
+	}
+
+	return &zktx.QueryProofsByRangeResponse{Payloads: results, Pagination: pageRes}, nil
+}
@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/indexes"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+// PayloadKey is the primary key of the ProvenPayload table: (contractHash, payloadIndex).
+type PayloadKey = collections.Pair[[]byte, uint32]
+
+// PayloadIndexes defines the secondary indexes kept alongside ProvenPayload so
+// callers can look up "all payloads for contract X", "...at height H", and
+// "...submitted by prover P" without scanning the whole table.
+type PayloadIndexes struct {
+	Contract *indexes.Multi[string, PayloadKey, zktx.PayloadMetadata]
+	Height   *indexes.Multi[uint64, PayloadKey, zktx.PayloadMetadata]
+	Prover   *indexes.Multi[string, PayloadKey, zktx.PayloadMetadata]
+	// ProofID is unique per accepted proof (it's assigned from Keeper.ProofSeq),
+	// so QueryProofsByRange can do an ordered range scan instead of a full walk.
+	ProofID *indexes.Unique[uint64, PayloadKey, zktx.PayloadMetadata]
+}
+
+func (i PayloadIndexes) IndexesList() []collections.Index[PayloadKey, zktx.PayloadMetadata] {
+	return []collections.Index[PayloadKey, zktx.PayloadMetadata]{i.Contract, i.Height, i.Prover, i.ProofID}
+}
+
+// NewPayloadIndexes registers the ProvenPayload secondary indexes on the schema builder.
+func NewPayloadIndexes(sb *collections.SchemaBuilder) PayloadIndexes {
+	return PayloadIndexes{
+		Contract: indexes.NewMulti(
+			sb, zktx.ProvenPayloadByContractIndexKey, "proven_payload_by_contract",
+			collections.StringKey, collections.PairKeyCodec(collections.BytesKey, collections.Uint32Key),
+			func(_ PayloadKey, v zktx.PayloadMetadata) (string, error) {
+				return v.ContractName, nil
+			},
+		),
+		Height: indexes.NewMulti(
+			sb, zktx.ProvenPayloadByHeightIndexKey, "proven_payload_by_height",
+			collections.Uint64Key, collections.PairKeyCodec(collections.BytesKey, collections.Uint32Key),
+			func(_ PayloadKey, v zktx.PayloadMetadata) (uint64, error) {
+				return v.BlockHeight, nil
+			},
+		),
+		Prover: indexes.NewMulti(
+			sb, zktx.ProvenPayloadByProverIndexKey, "proven_payload_by_prover",
+			collections.StringKey, collections.PairKeyCodec(collections.BytesKey, collections.Uint32Key),
+			func(_ PayloadKey, v zktx.PayloadMetadata) (string, error) {
+				return v.Prover, nil
+			},
+		),
+		ProofID: indexes.NewUnique(
+			sb, zktx.ProvenPayloadByProofIDIndexKey, "proven_payload_by_proof_id",
+			collections.Uint64Key, collections.PairKeyCodec(collections.BytesKey, collections.Uint32Key),
+			func(_ PayloadKey, v zktx.PayloadMetadata) (uint64, error) {
+				return v.ProofID, nil
+			},
+		),
+	}
+}
+
+// ContractIndexes defines the secondary indexes kept alongside Contracts.
+type ContractIndexes struct {
+	Owner *indexes.Multi[string, string, zktx.Contract]
+}
+
+func (i ContractIndexes) IndexesList() []collections.Index[string, zktx.Contract] {
+	return []collections.Index[string, zktx.Contract]{i.Owner}
+}
+
+// NewContractIndexes registers the Contracts secondary indexes on the schema builder.
+func NewContractIndexes(sb *collections.SchemaBuilder) ContractIndexes {
+	return ContractIndexes{
+		Owner: indexes.NewMulti(
+			sb, zktx.ContractByOwnerIndexKey, "contract_by_owner",
+			collections.StringKey, collections.StringKey,
+			func(_ string, v zktx.Contract) (string, error) {
+				return v.Owner, nil
+			},
+		),
+	}
+}
+
+// BackfillIndexes re-derives every secondary index entry from the primary
+// Contracts and ProvenPayload tables. IndexedMap keeps indexes in sync on every
+// Set call going forward, but state carried over from the plain-Map layout
+// predates that bookkeeping, so an in-place upgrade handler must call this once
+// to populate the new index tables from what's already on disk.
+func (k Keeper) BackfillIndexes(ctx context.Context) error {
+	// Collect everything to re-Set before writing any of it back: mutating a
+	// collection while Walk is still iterating it is an anti-pattern that
+	// only happens to be safe here because migrations run under a branched
+	// cachekv store. Closing the iterator before writing keeps this safe
+	// regardless of the store it runs against.
+	type contractEntry struct {
+		name     string
+		contract zktx.Contract
+	}
+	var contractEntries []contractEntry
+	err := k.Contracts.Walk(ctx, nil, func(name string, contract zktx.Contract) (stop bool, err error) {
+		contractEntries = append(contractEntries, contractEntry{name, contract})
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range contractEntries {
+		if err := k.Contracts.Set(ctx, e.name, e.contract); err != nil {
+			return err
+		}
+	}
+
+	type payloadEntry struct {
+		key     PayloadKey
+		payload zktx.PayloadMetadata
+	}
+	var payloadEntries []payloadEntry
+	err = k.ProvenPayload.Walk(ctx, nil, func(key PayloadKey, payload zktx.PayloadMetadata) (stop bool, err error) {
+		payloadEntries = append(payloadEntries, payloadEntry{key, payload})
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range payloadEntries {
+		// Every legacy entry carries the zero ProofID -- they predate ProofSeq --
+		// so re-Setting more than one as-is would collide on the ProofID unique
+		// index. Hand each one a fresh, genuinely unique ID from the same
+		// sequence real proof submissions draw from.
+		proofID, err := k.GetNextProofID(ctx)
+		if err != nil {
+			return err
+		}
+		e.payload.ProofID = proofID
+
+		if err := k.ProvenPayload.Set(ctx, e.key, e.payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
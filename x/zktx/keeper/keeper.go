@@ -22,11 +22,26 @@ type Keeper struct {
 	// state management
 	Schema    collections.Schema
 	Params    collections.Item[zktx.Params]
-	Contracts collections.Map[string, zktx.Contract]
+	Contracts collections.IndexedMap[string, zktx.Contract, ContractIndexes]
+
+	// Verifiers holds verifier public keys (Groth16, PlonK, STARK, ...) as
+	// first-class objects, keyed by verifier ID and referenced from Contract by
+	// that ID, so one verifier can be shared across many contracts.
+	Verifiers collections.Map[string, zktx.Verifier]
 
 	// Proof stuff
 	// NbPayload     collections.Map[string, uint16]
-	ProvenPayload collections.Map[collections.Pair[[]byte, uint32], zktx.PayloadMetadata]
+	ProvenPayload collections.IndexedMap[PayloadKey, zktx.PayloadMetadata, PayloadIndexes]
+
+	// ProofSeq assigns a globally increasing ProofID to every accepted proof, so
+	// relayers/indexers can stream new proofs in submission order.
+	ProofSeq collections.Sequence
+	// ContractNonce tracks the next nonce each contract's stateless verifier
+	// expects to see bound in a proof's public inputs, preventing replay.
+	ContractNonce collections.Map[string, uint64]
+
+	// Pruner drains ProvenPayload entries older than Params.PayloadRetentionBlocks.
+	Pruner PayloadPruner
 }
 
 // NewKeeper creates a new Keeper instance
@@ -40,12 +55,19 @@ func NewKeeper(cdc codec.BinaryCodec, addressCodec address.Codec, storeService s
 		cdc:          cdc,
 		addressCodec: addressCodec,
 		authority:    authority,
-		Params:       collections.NewItem(sb, zktx.ParamsKey, "params", codec.CollValue[zktx.Params](cdc)),
-		Contracts:    collections.NewMap(sb, zktx.ContractNameKey, "contracts", collections.StringKey, codec.CollValue[zktx.Contract](cdc)),
-		ProvenPayload: collections.NewMap(sb, zktx.ProvenPayloadKey, "proven_payload",
-			collections.PairKeyCodec(collections.BytesKey, collections.Uint32Key), codec.CollValue[zktx.PayloadMetadata](cdc)),
+		Params:    collections.NewItem(sb, zktx.ParamsKey, "params", codec.CollValue[zktx.Params](cdc)),
+		Contracts: collections.NewIndexedMap(sb, zktx.ContractNameKey, "contracts",
+			collections.StringKey, codec.CollValue[zktx.Contract](cdc), NewContractIndexes(sb)),
+		Verifiers: collections.NewMap(sb, zktx.VerifierKey, "verifiers", collections.StringKey, codec.CollValue[zktx.Verifier](cdc)),
+		ProvenPayload: collections.NewIndexedMap(sb, zktx.ProvenPayloadKey, "proven_payload",
+			collections.PairKeyCodec(collections.BytesKey, collections.Uint32Key), codec.CollValue[zktx.PayloadMetadata](cdc),
+			NewPayloadIndexes(sb)),
+		ProofSeq:      collections.NewSequence(sb, zktx.ProofSeqKey, "proof_seq"),
+		ContractNonce: collections.NewMap(sb, zktx.ContractNonceKey, "contract_nonce", collections.StringKey, collections.Uint64Value),
 	}
 
+	k.Pruner = NewPayloadPruner(sb, cdc, k)
+
 	schema, err := sb.Build()
 	if err != nil {
 		panic(err)
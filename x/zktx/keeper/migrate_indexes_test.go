@@ -0,0 +1,44 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"cosmossdk.io/collections"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyle-org/hyle/x/zktx"
+	"github.com/hyle-org/hyle/x/zktx/keeper"
+	keepertest "github.com/hyle-org/hyle/testutil/keeper"
+)
+
+// TestMigrate1to2BackfillsIndexes checks that legacy ProvenPayload entries --
+// each carrying the zero ProofID, since they predate ProofSeq -- survive the
+// backfill without tripping the ProofID unique index, and end up queryable
+// through the new by-contract index.
+func TestMigrate1to2BackfillsIndexes(t *testing.T) {
+	k, ctx := keepertest.ZktxKeeper(t)
+
+	const contractName = "legacy-contract"
+	contractHash := []byte("legacy-hash")
+	for i := uint32(0); i < 3; i++ {
+		pk := collections.Join(contractHash, i)
+		payload := zktx.PayloadMetadata{ContractName: contractName, BlockHeight: 1}
+		require.NoError(t, k.ProvenPayload.Set(ctx, pk, payload))
+		// Drop the index entries Set just wrote so only the primary record is
+		// left, as in state from the plain-Map layout; otherwise the next
+		// zero-ProofID Set would conflict on the ProofID unique index.
+		for _, idx := range k.ProvenPayload.Indexes.IndexesList() {
+			require.NoError(t, idx.Unreference(ctx, pk, func() (zktx.PayloadMetadata, error) { return payload, nil }))
+		}
+	}
+
+	m := keeper.NewMigrator(k)
+	require.NoError(t, m.Migrate1to2(ctx))
+
+	var found int
+	require.NoError(t, k.IterateByContract(ctx, contractName, func(_ keeper.PayloadKey, _ zktx.PayloadMetadata) (bool, error) {
+		found++
+		return false, nil
+	}))
+	require.Equal(t, 3, found)
+}
@@ -0,0 +1,45 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyle-org/hyle/x/zktx"
+	"github.com/hyle-org/hyle/x/zktx/keeper"
+	keepertest "github.com/hyle-org/hyle/testutil/keeper"
+)
+
+// TestMigrate2to3DedupesIdenticalVerifiers checks that two contracts carrying
+// byte-identical embedded verifiers are migrated onto a single Verifiers
+// entry, rather than each minting their own -- the whole point of splitting
+// verifiers out in the first place.
+func TestMigrate2to3DedupesIdenticalVerifiers(t *testing.T) {
+	k, ctx := keepertest.ZktxKeeper(t)
+
+	sameKey := []byte("same-verifier-key-bytes")
+	contracts := []zktx.Contract{
+		{Owner: "alice", DeprecatedVerifierScheme: zktx.VerifierScheme_VERIFIER_SCHEME_GROTH16, DeprecatedVerifierKey: sameKey},
+		{Owner: "bob", DeprecatedVerifierScheme: zktx.VerifierScheme_VERIFIER_SCHEME_GROTH16, DeprecatedVerifierKey: sameKey},
+	}
+	names := []string{"contract-a", "contract-b"}
+	for i, c := range contracts {
+		require.NoError(t, k.Contracts.Set(ctx, names[i], c))
+	}
+
+	m := keeper.NewMigrator(k)
+	require.NoError(t, m.Migrate2to3(ctx))
+
+	var ids []string
+	for _, name := range names {
+		migrated, err := k.Contracts.Get(ctx, name)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrated.VerifierID)
+		ids = append(ids, migrated.VerifierID)
+
+		verifier, err := k.Verifiers.Get(ctx, migrated.VerifierID)
+		require.NoError(t, err)
+		require.Equal(t, sameKey, verifier.Key)
+	}
+	require.Equal(t, ids[0], ids[1], "identical verifier bytes must collapse onto the same Verifiers entry")
+}
@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/types/module"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+// ConsensusVersion is returned by AppModule.ConsensusVersion. Bump it every
+// time RegisterMigrations below gains a new entry.
+const ConsensusVersion = 4
+
+// Migrator is a wrapper around Keeper used only for migrations. It implements
+// the module.MigrationHandler interface expected by module.RegisterMigration.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator for the given keeper.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 backfills the Contracts and ProvenPayload secondary indexes
+// introduced when those tables moved from collections.Map to
+// collections.IndexedMap. See Keeper.BackfillIndexes.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	return m.keeper.BackfillIndexes(ctx)
+}
+
+// Migrate2to3 splits the verifier key embedded in each Contract out into the
+// dedicated Verifiers table, so one verifier can be shared across many
+// contracts and governance can rotate it without redeploying every dependent
+// contract. It mirrors the slashing-keeper bech32->protobuf pubkey migration:
+// walk the old records, wrap the embedded bytes in the new typed proto, write
+// it under a fresh ID, then rewrite the old record to point at that ID.
+//
+// The verifier ID is derived from the verifier's own content (scheme + key),
+// not the contract name, so that contracts sharing byte-identical verifiers
+// collapse onto the same Verifiers entry instead of each minting their own.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	// Collect every contract needing migration before writing any of it
+	// back: mutating Contracts from inside its own Walk callback only
+	// happens to be safe because migrations run under a branched cachekv
+	// store. Closing the iterator before writing keeps this safe regardless
+	// of the store it runs against.
+	type contractEntry struct {
+		name     string
+		contract zktx.Contract
+	}
+	var entries []contractEntry
+	err := m.keeper.Contracts.Walk(ctx, nil, func(name string, contract zktx.Contract) (stop bool, err error) {
+		if contract.VerifierID != "" {
+			// Already migrated (e.g. re-run after a partial failure).
+			return false, nil
+		}
+		entries = append(entries, contractEntry{name, contract})
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		verifierID := verifierContentID(e.contract.DeprecatedVerifierScheme, e.contract.DeprecatedVerifierKey)
+		verifier := zktx.Verifier{
+			Scheme: e.contract.DeprecatedVerifierScheme,
+			Key:    e.contract.DeprecatedVerifierKey,
+		}
+
+		// Set is idempotent for identical content, so contracts that already
+		// share a verifier naturally dedupe onto this one entry.
+		if err := m.keeper.Verifiers.Set(ctx, verifierID, verifier); err != nil {
+			return err
+		}
+
+		e.contract.VerifierID = verifierID
+		e.contract.DeprecatedVerifierScheme = zktx.VerifierScheme_VERIFIER_SCHEME_UNSPECIFIED
+		e.contract.DeprecatedVerifierKey = nil
+
+		if err := m.keeper.Contracts.Set(ctx, e.name, e.contract); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Migrate3to4 backfills the prune schedule from ProvenPayload entries that
+// predate the pruner (see Keeper.Pruner.BackfillSchedule). Without this, any
+// payload proven before the pruner was introduced has no schedule entry and
+// would sit in state forever instead of eventually aging out.
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	return m.keeper.Pruner.BackfillSchedule(ctx)
+}
+
+// verifierContentID derives a stable, content-addressed Verifiers key from a
+// verifier's scheme and key bytes, so that two contracts pointing at
+// byte-identical verifiers are migrated onto the same table entry.
+func verifierContentID(scheme zktx.VerifierScheme, key []byte) string {
+	h := sha256.New()
+	var schemeBuf [4]byte
+	binary.BigEndian.PutUint32(schemeBuf[:], uint32(scheme))
+	h.Write(schemeBuf[:])
+	h.Write(key)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RegisterMigrations wires every in-place store migration this module has
+// accumulated onto the configurator. App wiring's RegisterServices should call
+// this once instead of registering each migration by hand.
+func RegisterMigrations(cfg module.Configurator, k Keeper) error {
+	m := NewMigrator(k)
+
+	if err := cfg.RegisterMigration(zktx.ModuleName, 1, m.Migrate1to2); err != nil {
+		return err
+	}
+	if err := cfg.RegisterMigration(zktx.ModuleName, 2, m.Migrate2to3); err != nil {
+		return err
+	}
+	return cfg.RegisterMigration(zktx.ModuleName, 3, m.Migrate3to4)
+}
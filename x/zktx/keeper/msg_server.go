@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+// NewMsgServerImpl returns an implementation of the zktx MsgServer interface
+// for the provided Keeper.
+func NewMsgServerImpl(keeper Keeper) zktx.MsgServer {
+	return &msgServer{Keeper: keeper}
+}
+
+var _ zktx.MsgServer = msgServer{}
+
+// SetRetention updates the payload retention window and per-block pruning cap.
+// It is gov-gated: only the module's authority may call it.
+func (k msgServer) SetRetention(ctx context.Context, msg *zktx.MsgSetRetention) (*zktx.MsgSetRetentionResponse, error) {
+	if k.GetAuthority() != msg.Authority {
+		return nil, fmt.Errorf("invalid authority: expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+
+	if msg.MaxPrunedPerBlock == 0 {
+		return nil, fmt.Errorf("max_pruned_per_block must be greater than 0, otherwise the pruner never runs")
+	}
+	if msg.PayloadRetentionBlocks == 0 {
+		return nil, fmt.Errorf("payload_retention_blocks must be greater than 0")
+	}
+
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params.PayloadRetentionBlocks = msg.PayloadRetentionBlocks
+	params.MaxPrunedPerBlock = msg.MaxPrunedPerBlock
+
+	if err := k.Params.Set(ctx, params); err != nil {
+		return nil, err
+	}
+
+	return &zktx.MsgSetRetentionResponse{}, nil
+}
+
+// SubmitProof accepts a proven payload for a contract. It is the handler
+// RecordProvenPayload exists for: it rejects the proof outright if msg.Nonce
+// doesn't match the contract's current on-chain nonce (replay protection),
+// otherwise it stamps and persists the payload and advances the nonce.
+//
+// This handler does not itself verify msg's proof, and so cannot confirm
+// that msg.Nonce is the value actually bound in the proof's public inputs --
+// it only checks msg.Nonce against on-chain state. Proof verification (and
+// with it, public-input binding of the nonce) is expected to run ahead of
+// this handler, e.g. in an ante handler or a verifier precompile that
+// rejects the message before it reaches the keeper. That verifier
+// integration is not part of this module; wiring it in is a prerequisite for
+// the replay protection here to mean anything.
+func (k msgServer) SubmitProof(ctx context.Context, msg *zktx.MsgSubmitProof) (*zktx.MsgSubmitProofResponse, error) {
+	payload := zktx.PayloadMetadata{
+		ContractName: msg.ContractName,
+		BlockHeight:  uint64(sdk.UnwrapSDKContext(ctx).BlockHeight()),
+		Prover:       msg.Prover,
+	}
+
+	if err := k.RecordProvenPayload(ctx, msg.ContractHash, msg.PayloadIndex, payload, msg.Nonce); err != nil {
+		return nil, err
+	}
+
+	return &zktx.MsgSubmitProofResponse{}, nil
+}
@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/collections"
+)
+
+// GetNextProofID atomically assigns and returns the next ProofID in the global
+// submission sequence, to be stamped on PayloadMetadata.ProofID.
+func (k Keeper) GetNextProofID(ctx context.Context) (uint64, error) {
+	return k.ProofSeq.Next(ctx)
+}
+
+// GetContractNonce returns the nonce a contract's next proof must bind in its
+// public inputs. Contracts that have never had a proof accepted start at 0.
+func (k Keeper) GetContractNonce(ctx context.Context, contractName string) (uint64, error) {
+	nonce, err := k.ContractNonce.Get(ctx, contractName)
+	if err != nil {
+		if errors.Is(err, collections.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// CheckAndIncrementNonce verifies that boundNonce matches the contract's
+// current nonce, rejecting the proof as a replay otherwise, and advances the
+// nonce so the next accepted proof must bind the following value.
+func (k Keeper) CheckAndIncrementNonce(ctx context.Context, contractName string, boundNonce uint64) error {
+	current, err := k.GetContractNonce(ctx, contractName)
+	if err != nil {
+		return err
+	}
+	if boundNonce != current {
+		return fmt.Errorf("invalid nonce for contract %s: expected %d, got %d", contractName, current, boundNonce)
+	}
+	return k.ContractNonce.Set(ctx, contractName, current+1)
+}
@@ -0,0 +1,56 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/hyle-org/hyle/testutil/keeper"
+)
+
+// TestNonceMismatchRejected checks that CheckAndIncrementNonce rejects a
+// bound nonce that doesn't equal the contract's current on-chain value
+// (replay protection), accepts the correct one, and advances the nonce so
+// the same bound value can't be replayed a second time.
+func TestNonceMismatchRejected(t *testing.T) {
+	k, ctx := keepertest.ZktxKeeper(t)
+
+	const contractName = "my-contract"
+
+	nonce, err := k.GetContractNonce(ctx, contractName)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), nonce)
+
+	// Wrong nonce is rejected and leaves the on-chain nonce unchanged.
+	err = k.CheckAndIncrementNonce(ctx, contractName, 1)
+	require.Error(t, err)
+
+	nonce, err = k.GetContractNonce(ctx, contractName)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), nonce)
+
+	// Correct nonce is accepted and advances the counter.
+	require.NoError(t, k.CheckAndIncrementNonce(ctx, contractName, 0))
+
+	nonce, err = k.GetContractNonce(ctx, contractName)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), nonce)
+
+	// Replaying the same (now stale) nonce is rejected.
+	err = k.CheckAndIncrementNonce(ctx, contractName, 0)
+	require.Error(t, err)
+}
+
+// TestGetNextProofIDMonotonic checks that ProofID assignment is strictly
+// increasing and never repeats across calls.
+func TestGetNextProofIDMonotonic(t *testing.T) {
+	k, ctx := keepertest.ZktxKeeper(t)
+
+	seen := make(map[uint64]bool)
+	for i := 0; i < 10; i++ {
+		id, err := k.GetNextProofID(ctx)
+		require.NoError(t, err)
+		require.False(t, seen[id], "ProofID %d assigned twice", id)
+		seen[id] = true
+	}
+}
@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+// RecordProvenPayload is the single path that accepts a newly-proven payload
+// into state. It rejects the proof if boundNonce doesn't match the
+// contract's current nonce (replay protection), stamps the payload with the
+// next global ProofID and the now-consumed nonce, persists it, and schedules
+// it for pruning. Every msg handler that writes to ProvenPayload must go
+// through this rather than calling k.ProvenPayload.Set directly.
+//
+// boundNonce is trusted as-is: this keeper only checks it against the
+// on-chain sequence, it does not itself verify that boundNonce is the value
+// the submitted proof's public inputs actually commit to. That binding check
+// is the verifier's job and must happen before RecordProvenPayload is called
+// -- a verifier integration that skips it lets a caller pass any boundNonce
+// it likes, defeating replay protection entirely.
+func (k Keeper) RecordProvenPayload(ctx context.Context, contractHash []byte, payloadIndex uint32, payload zktx.PayloadMetadata, boundNonce uint64) error {
+	if err := k.CheckAndIncrementNonce(ctx, payload.ContractName, boundNonce); err != nil {
+		return err
+	}
+
+	proofID, err := k.GetNextProofID(ctx)
+	if err != nil {
+		return err
+	}
+	payload.ProofID = proofID
+	payload.Nonce = boundNonce
+
+	if err := k.ProvenPayload.Set(ctx, collections.Join(contractHash, payloadIndex), payload); err != nil {
+		return err
+	}
+
+	return k.Pruner.Schedule(ctx, contractHash, payloadIndex, payload.BlockHeight)
+}
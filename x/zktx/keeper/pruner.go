@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+
+	"cosmossdk.io/collections"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+// pruneScheduleKey is the auxiliary scheduling table key: the block height at
+// which a payload was proven, paired with the contract hash it was proven
+// against. The value is the set of payload indexes due at that
+// height, mirroring wasmd's AccountPruner queue.
+type pruneScheduleKey = collections.Pair[uint64, []byte]
+
+// PayloadPruner schedules ProvenPayload entries for deletion once the current
+// height is at least Params.PayloadRetentionBlocks past the height they were
+// proven at, and drains that
+// schedule in BeginBlock so state doesn't grow unboundedly.
+type PayloadPruner struct {
+	keeper   Keeper
+	schedule collections.Map[pruneScheduleKey, zktx.PrunedPayloadIndexes]
+}
+
+// NewPayloadPruner builds the pruner's scheduling map on the keeper's schema builder.
+func NewPayloadPruner(sb *collections.SchemaBuilder, cdc codec.BinaryCodec, keeper Keeper) PayloadPruner {
+	return PayloadPruner{
+		keeper: keeper,
+		schedule: collections.NewMap(sb, zktx.PayloadPruneScheduleKey, "payload_prune_schedule",
+			collections.PairKeyCodec(collections.Uint64Key, collections.BytesKey),
+			codec.CollValue[zktx.PrunedPayloadIndexes](cdc)),
+	}
+}
+
+// Schedule marks a freshly-proven payload for deletion once it reaches the
+// configured retention window. Called from the same handler that writes the
+// payload to ProvenPayload.
+func (p PayloadPruner) Schedule(ctx context.Context, contractHash []byte, payloadIndex uint32, provenAtHeight uint64) error {
+	key := collections.Join(provenAtHeight, contractHash)
+
+	pending, err := p.schedule.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, collections.ErrNotFound) {
+			return err
+		}
+		pending = zktx.PrunedPayloadIndexes{}
+	}
+	pending.Indexes = append(pending.Indexes, payloadIndex)
+	return p.schedule.Set(ctx, key, pending)
+}
+
+// PendingCount returns how many payloads are still queued for pruning, backing
+// the QueryPendingPruneCount gRPC endpoint.
+func (p PayloadPruner) PendingCount(ctx context.Context) (uint64, error) {
+	var count uint64
+	err := p.schedule.Walk(ctx, nil, func(_ pruneScheduleKey, pending zktx.PrunedPayloadIndexes) (stop bool, err error) {
+		count += uint64(len(pending.Indexes))
+		return false, nil
+	})
+	return count, err
+}
+
+// Prune walks every schedule entry due at or before currentHeight-retention and
+// deletes the corresponding ProvenPayload entries, capped at maxPerBlock
+// deletions so a single block can't be stalled by a large backlog. It returns
+// the number of payloads actually pruned.
+func (p PayloadPruner) Prune(ctx context.Context, currentHeight, retention, maxPerBlock uint64) (uint64, error) {
+	if currentHeight < retention {
+		return 0, nil
+	}
+	cutoff := currentHeight - retention
+
+	// Bound the range on the height component alone: the end key's contract
+	// hash is the empty string, which sorts before every real hash at height
+	// cutoff+1, so the range still covers every entry with height <= cutoff
+	// regardless of hash length. A fabricated fixed-width "max hash" byte
+	// string would instead sort below any longer hash sharing that prefix and
+	// silently skip entries.
+	endHeight := cutoff + 1
+	if cutoff == math.MaxUint64 {
+		endHeight = cutoff
+	}
+	rng := new(collections.Range[pruneScheduleKey]).EndExclusive(collections.Join(endHeight, []byte{}))
+
+	var pruned uint64
+	var drained []pruneScheduleKey
+	err := p.schedule.Walk(ctx, rng, func(key pruneScheduleKey, pending zktx.PrunedPayloadIndexes) (stop bool, err error) {
+		contractHash := key.K2()
+		remaining := pending.Indexes[:0]
+
+		for i, idx := range pending.Indexes {
+			if pruned >= maxPerBlock {
+				remaining = pending.Indexes[i:]
+				break
+			}
+			// A schedule entry can reference a ProvenPayload key that's already
+			// gone -- e.g. RecordProvenPayload overwrote the same (contractHash,
+			// payloadIndex) a second time and scheduled it again, queuing the
+			// same primary key twice. Removing an already-removed key is a
+			// no-op, not a failure; propagating ErrNotFound here would return
+			// an error out of BeginBlock and halt the chain.
+			if err := p.keeper.ProvenPayload.Remove(ctx, collections.Join(contractHash, idx)); err != nil && !errors.Is(err, collections.ErrNotFound) {
+				return true, err
+			}
+			pruned++
+
+			sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+				"payload_pruned",
+				sdk.NewAttribute("contract_hash", hex.EncodeToString(contractHash)),
+				sdk.NewAttribute("payload_index", strconv.FormatUint(uint64(idx), 10)),
+			))
+		}
+
+		if len(remaining) == 0 {
+			drained = append(drained, key)
+		} else {
+			pending.Indexes = remaining
+			if err := p.schedule.Set(ctx, key, pending); err != nil {
+				return true, err
+			}
+		}
+
+		return pruned >= maxPerBlock, nil
+	})
+	if err != nil {
+		return pruned, err
+	}
+
+	for _, key := range drained {
+		if err := p.schedule.Remove(ctx, key); err != nil {
+			return pruned, err
+		}
+	}
+
+	return pruned, nil
+}
+
+// BackfillSchedule populates the prune schedule from ProvenPayload entries that
+// predate the pruner, using each entry's BlockHeight field. An upgrade handler
+// introducing the pruner for the first time must call this once so existing
+// payloads are eventually pruned rather than sitting in state forever.
+func (p PayloadPruner) BackfillSchedule(ctx context.Context) error {
+	return p.keeper.ProvenPayload.Walk(ctx, nil, func(key PayloadKey, payload zktx.PayloadMetadata) (stop bool, err error) {
+		return false, p.Schedule(ctx, key.K1(), key.K2(), payload.BlockHeight)
+	})
+}
@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+
+	"github.com/hyle-org/hyle/x/zktx"
+)
+
+// IterateByContract walks every ProvenPayload entry for the given contract name in
+// key order, invoking cb for each one. Iteration stops early if cb returns true.
+func (k Keeper) IterateByContract(ctx context.Context, contractName string, cb func(key PayloadKey, payload zktx.PayloadMetadata) (stop bool, err error)) error {
+	rng := collections.NewPrefixedPairRange[string, PayloadKey](contractName)
+	iter, err := k.ProvenPayload.Indexes.Contract.Iterate(ctx, rng)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		pk, err := iter.PrimaryKey()
+		if err != nil {
+			return err
+		}
+		payload, err := k.ProvenPayload.Get(ctx, pk)
+		if err != nil {
+			return err
+		}
+		stop, err := cb(pk, payload)
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+// IterateByHeight walks every ProvenPayload entry submitted at the given block height.
+func (k Keeper) IterateByHeight(ctx context.Context, height uint64, cb func(key PayloadKey, payload zktx.PayloadMetadata) (stop bool, err error)) error {
+	rng := collections.NewPrefixedPairRange[uint64, PayloadKey](height)
+	iter, err := k.ProvenPayload.Indexes.Height.Iterate(ctx, rng)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		pk, err := iter.PrimaryKey()
+		if err != nil {
+			return err
+		}
+		payload, err := k.ProvenPayload.Get(ctx, pk)
+		if err != nil {
+			return err
+		}
+		stop, err := cb(pk, payload)
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+// IterateByProver walks every ProvenPayload entry submitted by the given prover address.
+func (k Keeper) IterateByProver(ctx context.Context, prover string, cb func(key PayloadKey, payload zktx.PayloadMetadata) (stop bool, err error)) error {
+	rng := collections.NewPrefixedPairRange[string, PayloadKey](prover)
+	iter, err := k.ProvenPayload.Indexes.Prover.Iterate(ctx, rng)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		pk, err := iter.PrimaryKey()
+		if err != nil {
+			return err
+		}
+		payload, err := k.ProvenPayload.Get(ctx, pk)
+		if err != nil {
+			return err
+		}
+		stop, err := cb(pk, payload)
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}